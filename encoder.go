@@ -0,0 +1,40 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package log
+
+import "go.uber.org/zap/zapcore"
+
+// WithRFC3339TimeEncoder returns an EncoderConfigOptions entry that
+// switches the timestamp encoding to RFC3339Nano instead of the default
+// ISO8601 format.
+func WithRFC3339TimeEncoder() func(*zapcore.EncoderConfig) {
+	return func(c *zapcore.EncoderConfig) {
+		c.EncodeTime = zapcore.RFC3339NanoTimeEncoder
+	}
+}
+
+// WithShortCallerEncoder returns an EncoderConfigOptions entry that
+// encodes the caller field as `package/file:line`. NewLogger's base
+// encoder config already uses this encoder, so the entry only changes
+// the output when an earlier entry replaced EncodeCaller.
+func WithShortCallerEncoder() func(*zapcore.EncoderConfig) {
+	return func(c *zapcore.EncoderConfig) {
+		c.EncodeCaller = zapcore.ShortCallerEncoder
+	}
+}
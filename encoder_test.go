@@ -0,0 +1,72 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestEncoderConfigOptionsMutateBuiltEncoder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.log")
+
+	opts := NewOptions()
+	opts.Format = jsonFormat
+	opts.OutputPaths = []string{path}
+	opts.EncoderConfigOptions = []func(*zapcore.EncoderConfig){
+		func(c *zapcore.EncoderConfig) { c.MessageKey = "message" },
+	}
+
+	l := NewLogger(opts)
+	l.Info("custom message key")
+	l.Flush()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+
+	if !strings.Contains(string(data), `"message":"custom message key"`) {
+		t.Fatalf("expected EncoderConfigOptions to rename the message key, got:\n%s", data)
+	}
+}
+
+func TestWithShortCallerEncoder(t *testing.T) {
+	var cfg zapcore.EncoderConfig
+
+	WithShortCallerEncoder()(&cfg)
+
+	if cfg.EncodeCaller == nil {
+		t.Fatal("WithShortCallerEncoder did not set EncodeCaller")
+	}
+}
+
+func TestWithRFC3339TimeEncoder(t *testing.T) {
+	var cfg zapcore.EncoderConfig
+
+	WithRFC3339TimeEncoder()(&cfg)
+
+	if cfg.EncodeTime == nil {
+		t.Fatal("WithRFC3339TimeEncoder did not set EncodeTime")
+	}
+}
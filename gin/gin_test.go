@@ -0,0 +1,62 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	log "github.com/huanghe314/log-tke"
+)
+
+func TestMiddlewareLogsRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	path := filepath.Join(t.TempDir(), "out.log")
+	opts := log.NewOptions()
+	opts.OutputPaths = []string{path}
+	l := log.NewLogger(opts)
+
+	r := gin.New()
+	r.Use(Middleware(l))
+	r.GET("/widgets", func(c *gin.Context) { c.Status(http.StatusTeapot) })
+
+	req := httptest.NewRequest(http.MethodGet, "/widgets?color=red", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	l.Flush()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+
+	out := string(data)
+	for _, want := range []string{"GET", "/widgets?color=red", "418"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected request log to contain %q, got:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+// Package klog redirects Kubernetes' k8s.io/klog/v2 output into this
+// repository's log.Logger, so a single zap pipeline ends up as the only
+// place logs are written.
+package klog
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"go.uber.org/zap"
+	"k8s.io/klog/v2"
+
+	"github.com/huanghe314/log-tke"
+)
+
+// Option configures the klog bridge built by InitKlog.
+type Option func(*sink)
+
+// WithVerbosity sets the maximum klog V-level InitKlog's sink reports as
+// enabled, e.g. klog.V(5).Info(...) is only logged when level is at least
+// 5. Defaults to 0, i.e. only unconditional (V(0)) logging.
+func WithVerbosity(level int) Option {
+	return func(s *sink) { s.verbosity = level }
+}
+
+// InitKlog redirects klog's output into l. Contextual logging is enabled
+// so that klog.Background/FromContext callers, not just the package-level
+// klog.Info-style functions, are routed through l and gated by the
+// verbosity configured via WithVerbosity.
+func InitKlog(l log.Logger, opts ...Option) {
+	s := &sink{l: l}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	klog.EnableContextualLogging(true)
+	klog.SetLoggerWithOptions(logr.New(s), klog.ContextualLogger(true))
+}
+
+// sink implements logr.LogSink on top of log.Logger.
+type sink struct {
+	l         log.Logger
+	name      string
+	verbosity int
+}
+
+var _ logr.LogSink = &sink{}
+
+func (s *sink) Init(logr.RuntimeInfo) {}
+
+func (s *sink) Enabled(level int) bool { return level <= s.verbosity }
+
+func (s *sink) Info(_ int, msg string, keysAndValues ...interface{}) {
+	s.l.WithValues(keysAndValues...).Info(s.prefix(msg))
+}
+
+func (s *sink) Error(err error, msg string, keysAndValues ...interface{}) {
+	s.l.WithValues(keysAndValues...).Errorw(s.prefix(msg), zap.Error(err))
+}
+
+func (s *sink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return &sink{l: s.l.WithValues(keysAndValues...), name: s.name, verbosity: s.verbosity}
+}
+
+func (s *sink) WithName(name string) logr.LogSink {
+	if s.name != "" {
+		name = s.name + "." + name
+	}
+
+	return &sink{l: s.l, name: name, verbosity: s.verbosity}
+}
+
+func (s *sink) prefix(msg string) string {
+	if s.name == "" {
+		return msg
+	}
+
+	return fmt.Sprintf("[%s] %s", s.name, msg)
+}
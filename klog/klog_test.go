@@ -0,0 +1,66 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package klog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	log "github.com/huanghe314/log-tke"
+	"k8s.io/klog/v2"
+)
+
+func TestSinkEnabledRespectsVerbosity(t *testing.T) {
+	s := &sink{verbosity: 2}
+
+	if !s.Enabled(0) || !s.Enabled(2) {
+		t.Fatalf("expected levels at or below the configured verbosity to be enabled")
+	}
+	if s.Enabled(3) {
+		t.Fatalf("expected a level above the configured verbosity to be disabled")
+	}
+}
+
+func TestInitKlogRedirectsOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.log")
+	opts := log.NewOptions()
+	opts.OutputPaths = []string{path}
+	l := log.NewLogger(opts)
+
+	InitKlog(l, WithVerbosity(1))
+
+	klog.Background().V(1).Info("via klog bridge")
+	klog.Background().V(5).Info("too verbose, should be dropped")
+	l.Flush()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+
+	out := string(data)
+	if !strings.Contains(out, "via klog bridge") {
+		t.Errorf("expected V(1) message to be logged, got:\n%s", out)
+	}
+	if strings.Contains(out, "too verbose") {
+		t.Errorf("expected V(5) message to be dropped by the verbosity threshold, got:\n%s", out)
+	}
+}
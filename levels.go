@@ -0,0 +1,158 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package log
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// levels is the process-wide registry of named loggers' AtomicLevel,
+// keyed by the dotted name built up through successive Named calls. The
+// root logger is registered under its own, possibly empty, Options.Name.
+var (
+	levelsMu sync.RWMutex
+	levels   = map[string]*zap.AtomicLevel{}
+)
+
+// parseLevel parses a log level the same way Options.Validate does.
+func parseLevel(level string) (zapcore.Level, error) {
+	var l zapcore.Level
+	err := l.UnmarshalText([]byte(level))
+
+	return l, err
+}
+
+// levelFor returns the AtomicLevel registered for name, creating one set
+// to initial if this is the first time name is seen.
+func levelFor(name string, initial zapcore.Level) *zap.AtomicLevel {
+	levelsMu.Lock()
+	defer levelsMu.Unlock()
+
+	if l, ok := levels[name]; ok {
+		return l
+	}
+
+	l := zap.NewAtomicLevelAt(initial)
+	levels[name] = &l
+
+	return &l
+}
+
+// leveledCore wraps a zapcore.Core so it can be enabled/disabled through
+// an AtomicLevel of its own, independently of the Core it wraps.
+type leveledCore struct {
+	zapcore.Core
+	level zapcore.LevelEnabler
+}
+
+func (c *leveledCore) Enabled(lvl zapcore.Level) bool { return c.level.Enabled(lvl) }
+
+func (c *leveledCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.level.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+
+	return ce
+}
+
+func (c *leveledCore) With(fields []zapcore.Field) zapcore.Core {
+	return &leveledCore{Core: c.Core.With(fields), level: c.level}
+}
+
+// Named returns a sub-logger of the package-level default Logger.
+func Named(name string) Logger { return std.Named(name) }
+
+func (l *zapLogger) Named(name string) Logger {
+	full := name
+	if l.name != "" {
+		full = l.name + "." + name
+	}
+
+	level := levelFor(full, l.level.Level())
+
+	logger := l.zapLogger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &leveledCore{Core: core, level: level}
+	})).Named(name)
+
+	return &zapLogger{zapLogger: logger, name: full, level: level}
+}
+
+// levelEntry is the wire format used by LevelHandler for both listing and
+// updating a named logger's level.
+type levelEntry struct {
+	Name  string `json:"name"`
+	Level string `json:"level"`
+}
+
+// LevelHandler returns an http.Handler that exposes the registry of named
+// logger levels: GET lists every registered name and its current level,
+// PUT/POST with a JSON body {"name":"scheduler","level":"debug"} changes
+// one live, without a restart.
+func LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			listLevels(w)
+		case http.MethodPut, http.MethodPost:
+			setLevel(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func listLevels(w http.ResponseWriter) {
+	levelsMu.RLock()
+	entries := make([]levelEntry, 0, len(levels))
+	for name, l := range levels {
+		entries = append(entries, levelEntry{Name: name, Level: l.Level().String()})
+	}
+	levelsMu.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(entries)
+}
+
+func setLevel(w http.ResponseWriter, r *http.Request) {
+	var req levelEntry
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	zapLevel, err := parseLevel(req.Level)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	levelFor(req.Name, zapLevel).SetLevel(zapLevel)
+
+	w.WriteHeader(http.StatusNoContent)
+}
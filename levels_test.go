@@ -0,0 +1,121 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLevelHandlerGetAndPut(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.log")
+
+	opts := NewOptions()
+	opts.OutputPaths = []string{path}
+	l := NewLogger(opts)
+
+	scheduler := l.Named("chunk0-5-scheduler")
+	sibling := l.Named("chunk0-5-sibling")
+
+	scheduler.Debug("scheduler before bump")
+	sibling.Debug("sibling before bump")
+	l.Flush()
+
+	assertLogged(t, path, "scheduler before bump", false)
+	assertLogged(t, path, "sibling before bump", false)
+
+	srv := httptest.NewServer(LevelHandler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("GET %s: %v", srv.URL, err)
+	}
+	defer resp.Body.Close()
+
+	var before []levelEntry
+	if err := json.NewDecoder(resp.Body).Decode(&before); err != nil {
+		t.Fatalf("decode GET response: %v", err)
+	}
+
+	if !containsLevel(before, "chunk0-5-scheduler", "info") {
+		t.Fatalf("expected chunk0-5-scheduler to be registered at info, got %+v", before)
+	}
+
+	body, err := json.Marshal(levelEntry{Name: "chunk0-5-scheduler", Level: "debug"})
+	if err != nil {
+		t.Fatalf("marshal PUT body: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, srv.URL, bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("build PUT request: %v", err)
+	}
+
+	putResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("PUT %s: %v", srv.URL, err)
+	}
+	defer putResp.Body.Close()
+
+	if putResp.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected %d from PUT, got %d", http.StatusNoContent, putResp.StatusCode)
+	}
+
+	scheduler.Debug("scheduler after bump")
+	sibling.Debug("sibling after bump")
+	l.Flush()
+
+	assertLogged(t, path, "scheduler after bump", true)
+	assertLogged(t, path, "sibling after bump", false)
+}
+
+func containsLevel(entries []levelEntry, name, level string) bool {
+	for _, e := range entries {
+		if e.Name == name {
+			return e.Level == level
+		}
+	}
+
+	return false
+}
+
+func assertLogged(t *testing.T, path, msg string, want bool) {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && !want {
+			return
+		}
+
+		t.Fatalf("read %s: %v", path, err)
+	}
+
+	got := strings.Contains(string(data), msg)
+	if got != want {
+		t.Fatalf("expected log containing %q to be %v, got %v\ncontents:\n%s", msg, want, got, data)
+	}
+}
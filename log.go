@@ -0,0 +1,265 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package log
+
+import (
+	"context"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Logger is the interface implemented by this package's structured logger.
+// It exposes the usual level-based methods in three flavors: a bare
+// variant that behaves like fmt.Sprint, an "f" printf-style variant and a
+// "w" variant that accepts structured zap.Field values.
+type Logger interface {
+	Debug(args ...interface{})
+	Debugf(format string, args ...interface{})
+	Debugw(msg string, fields ...zap.Field)
+
+	Info(args ...interface{})
+	Infof(format string, args ...interface{})
+	Infow(msg string, fields ...zap.Field)
+
+	Warn(args ...interface{})
+	Warnf(format string, args ...interface{})
+	Warnw(msg string, fields ...zap.Field)
+
+	Error(args ...interface{})
+	Errorf(format string, args ...interface{})
+	Errorw(msg string, fields ...zap.Field)
+
+	Panic(args ...interface{})
+	Panicf(format string, args ...interface{})
+	Panicw(msg string, fields ...zap.Field)
+
+	Fatal(args ...interface{})
+	Fatalf(format string, args ...interface{})
+	Fatalw(msg string, fields ...zap.Field)
+
+	// WithValues returns a Logger that always carries the given
+	// key-value pairs, so callers don't need to repeat them on every
+	// call.
+	WithValues(keysAndValues ...interface{}) Logger
+
+	// WithContext stashes the Logger on ctx so it can later be
+	// retrieved with FromContext.
+	WithContext(ctx context.Context) context.Context
+
+	// Named returns a sub-logger whose level can be adjusted at runtime,
+	// independently of this Logger's level, through LevelHandler.
+	Named(name string) Logger
+
+	// Flush flushes any buffered log entries.
+	Flush()
+}
+
+// zapLogger is the default Logger implementation backed by zap.
+type zapLogger struct {
+	zapLogger *zap.Logger
+	name      string
+	level     *zap.AtomicLevel
+}
+
+var _ Logger = &zapLogger{}
+
+// std is the name-less, default global Logger.
+var std = NewLogger(NewOptions())
+
+// NewLogger creates a Logger from the given Options.
+func NewLogger(opts *Options) Logger {
+	if opts == nil {
+		opts = NewOptions()
+	}
+
+	format := strings.ToLower(opts.Format)
+
+	encoderConfig := zap.NewProductionEncoderConfig()
+	if format == consoleFormat {
+		encoderConfig = zap.NewDevelopmentEncoderConfig()
+	}
+
+	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	if opts.EnableColor {
+		encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	} else {
+		encoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+	}
+
+	for _, f := range opts.EncoderConfigOptions {
+		f(&encoderConfig)
+	}
+
+	registerLumberjackSink()
+
+	loggerConfig := &zap.Config{
+		Level:             zap.NewAtomicLevelAt(zapcore.InfoLevel),
+		Development:       opts.Development,
+		DisableCaller:     !opts.EnableCaller,
+		DisableStacktrace: opts.DisableStacktrace,
+		Sampling: &zap.SamplingConfig{
+			Initial:    100,
+			Thereafter: 100,
+		},
+		Encoding:         format,
+		EncoderConfig:    encoderConfig,
+		OutputPaths:      rotatingOutputPaths(opts.OutputPaths, opts),
+		ErrorOutputPaths: opts.ErrorOutputPaths,
+	}
+
+	zapLevel, err := parseLevel(opts.Level)
+	if err != nil {
+		zapLevel = zapcore.InfoLevel
+	}
+
+	level := levelFor(opts.Name, zapLevel)
+	level.SetLevel(zapLevel)
+	loggerConfig.Level = *level
+
+	var buildOpts []zap.Option
+	if !opts.DisableStacktrace {
+		buildOpts = append(buildOpts, zap.AddStacktrace(zapcore.PanicLevel))
+	}
+
+	l, err := loggerConfig.Build(buildOpts...)
+	if err != nil {
+		panic(err)
+	}
+
+	logger := l.Named(opts.Name)
+
+	return &zapLogger{zapLogger: logger, name: opts.Name, level: level}
+}
+
+// Init initializes the package-level default Logger from opts.
+func Init(opts *Options) {
+	std = NewLogger(opts)
+}
+
+func (l *zapLogger) Debug(args ...interface{}) { l.zapLogger.Sugar().Debug(args...) }
+
+func (l *zapLogger) Debugf(format string, args ...interface{}) {
+	l.zapLogger.Sugar().Debugf(format, args...)
+}
+
+func (l *zapLogger) Debugw(msg string, fields ...zap.Field) { l.zapLogger.Debug(msg, fields...) }
+
+func (l *zapLogger) Info(args ...interface{}) { l.zapLogger.Sugar().Info(args...) }
+
+func (l *zapLogger) Infof(format string, args ...interface{}) {
+	l.zapLogger.Sugar().Infof(format, args...)
+}
+
+func (l *zapLogger) Infow(msg string, fields ...zap.Field) { l.zapLogger.Info(msg, fields...) }
+
+func (l *zapLogger) Warn(args ...interface{}) { l.zapLogger.Sugar().Warn(args...) }
+
+func (l *zapLogger) Warnf(format string, args ...interface{}) {
+	l.zapLogger.Sugar().Warnf(format, args...)
+}
+
+func (l *zapLogger) Warnw(msg string, fields ...zap.Field) { l.zapLogger.Warn(msg, fields...) }
+
+func (l *zapLogger) Error(args ...interface{}) { l.zapLogger.Sugar().Error(args...) }
+
+func (l *zapLogger) Errorf(format string, args ...interface{}) {
+	l.zapLogger.Sugar().Errorf(format, args...)
+}
+
+func (l *zapLogger) Errorw(msg string, fields ...zap.Field) { l.zapLogger.Error(msg, fields...) }
+
+func (l *zapLogger) Panic(args ...interface{}) { l.zapLogger.Sugar().Panic(args...) }
+
+func (l *zapLogger) Panicf(format string, args ...interface{}) {
+	l.zapLogger.Sugar().Panicf(format, args...)
+}
+
+func (l *zapLogger) Panicw(msg string, fields ...zap.Field) { l.zapLogger.Panic(msg, fields...) }
+
+func (l *zapLogger) Fatal(args ...interface{}) { l.zapLogger.Sugar().Fatal(args...) }
+
+func (l *zapLogger) Fatalf(format string, args ...interface{}) {
+	l.zapLogger.Sugar().Fatalf(format, args...)
+}
+
+func (l *zapLogger) Fatalw(msg string, fields ...zap.Field) { l.zapLogger.Fatal(msg, fields...) }
+
+func (l *zapLogger) WithValues(keysAndValues ...interface{}) Logger {
+	logger := l.zapLogger.Sugar().With(keysAndValues...).Desugar()
+
+	return &zapLogger{zapLogger: logger, name: l.name, level: l.level}
+}
+
+func (l *zapLogger) WithContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+func (l *zapLogger) Flush() { _ = l.zapLogger.Sync() }
+
+// loggerKey is the unexported type used as the context key under which a
+// Logger is stored; using a dedicated type avoids collisions with keys
+// defined in other packages.
+type loggerKey struct{}
+
+// FromContext returns the Logger stashed on ctx by a prior call to
+// WithContext, or the package-level default Logger if ctx carries none.
+func FromContext(ctx context.Context) Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(loggerKey{}).(Logger); ok {
+			return l
+		}
+	}
+
+	return std
+}
+
+// WithContext stashes the package-level default Logger on ctx.
+func WithContext(ctx context.Context) context.Context {
+	return std.WithContext(ctx)
+}
+
+// WithValues returns a Logger derived from the package-level default
+// Logger that always carries the given key-value pairs.
+func WithValues(keysAndValues ...interface{}) Logger {
+	return std.WithValues(keysAndValues...)
+}
+
+// Flush flushes the package-level default Logger.
+func Flush() { std.Flush() }
+
+func Debug(args ...interface{})                 { std.Debug(args...) }
+func Debugf(format string, args ...interface{}) { std.Debugf(format, args...) }
+func Debugw(msg string, fields ...zap.Field)    { std.Debugw(msg, fields...) }
+func Info(args ...interface{})                  { std.Info(args...) }
+func Infof(format string, args ...interface{})  { std.Infof(format, args...) }
+func Infow(msg string, fields ...zap.Field)     { std.Infow(msg, fields...) }
+func Warn(args ...interface{})                  { std.Warn(args...) }
+func Warnf(format string, args ...interface{})  { std.Warnf(format, args...) }
+func Warnw(msg string, fields ...zap.Field)     { std.Warnw(msg, fields...) }
+func Error(args ...interface{})                 { std.Error(args...) }
+func Errorf(format string, args ...interface{}) { std.Errorf(format, args...) }
+func Errorw(msg string, fields ...zap.Field)    { std.Errorw(msg, fields...) }
+func Panic(args ...interface{})                 { std.Panic(args...) }
+func Panicf(format string, args ...interface{}) { std.Panicf(format, args...) }
+func Panicw(msg string, fields ...zap.Field)    { std.Panicw(msg, fields...) }
+func Fatal(args ...interface{})                 { std.Fatal(args...) }
+func Fatalf(format string, args ...interface{}) { std.Fatalf(format, args...) }
+func Fatalw(msg string, fields ...zap.Field)    { std.Fatalw(msg, fields...) }
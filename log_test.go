@@ -0,0 +1,93 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package log
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFromContextRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.log")
+
+	opts := NewOptions()
+	opts.OutputPaths = []string{path}
+	l := NewLogger(opts)
+
+	if got := FromContext(context.Background()); got != std {
+		t.Fatalf("FromContext(context.Background()) = %v, want the package default std logger", got)
+	}
+
+	ctx := l.WithContext(context.Background())
+
+	got := FromContext(ctx)
+	if got != l {
+		t.Fatalf("FromContext(WithContext(ctx)) did not round-trip to the original Logger")
+	}
+
+	got.Info("round-tripped")
+	l.Flush()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+
+	if !strings.Contains(string(data), "round-tripped") {
+		t.Fatalf("expected log output to contain %q, got:\n%s", "round-tripped", data)
+	}
+}
+
+func TestWithValuesCarriesFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.log")
+
+	opts := NewOptions()
+	opts.Format = jsonFormat
+	opts.OutputPaths = []string{path}
+	l := NewLogger(opts)
+
+	l.WithValues("request-id", "abc-123").Info("handled request")
+	l.Flush()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+
+	if !strings.Contains(string(data), `"request-id":"abc-123"`) {
+		t.Fatalf("expected log output to carry request-id field, got:\n%s", data)
+	}
+}
+
+func TestNewLoggerNormalizesFormatCase(t *testing.T) {
+	opts := NewOptions()
+	opts.Format = "JSON"
+	opts.OutputPaths = []string{"stdout"}
+
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("NewLogger panicked on mixed-case Format %q: %v", opts.Format, r)
+		}
+	}()
+
+	NewLogger(opts)
+}
@@ -39,6 +39,8 @@ const (
 	flagDisableStacktrace = "log.disable-stacktrace"
 	flagMaxSizeInMB       = "log.max-size-mb"
 	flagMaxAgeInDays      = "log.max-age-days"
+	flagMaxBackups        = "log.max-backups"
+	flagCompress          = "log.compress"
 
 	consoleFormat = "console"
 	jsonFormat    = "json"
@@ -57,6 +59,15 @@ type Options struct {
 	Name              string   `json:"name"               mapstructure:"name"`
 	MaxSizeInMB       int      `json:"max-size-in-mb"     mapstructure:"max-size-in-mb"`
 	MaxAgeInDays      int      `json:"max-age-in-days"    mapstructure:"max-age-in-days"`
+	MaxBackups        int      `json:"max-backups"        mapstructure:"max-backups"`
+	Compress          bool     `json:"compress"           mapstructure:"compress"`
+
+	// EncoderConfigOptions let callers customize the zapcore.EncoderConfig
+	// used to build the logger (timestamp format, level/message key
+	// names, custom encoders, ...) beyond what the flags above expose.
+	// Not serialized, so it is only settable in code, e.g. via
+	// WithRFC3339TimeEncoder or WithShortCallerEncoder.
+	EncoderConfigOptions []func(*zapcore.EncoderConfig) `json:"-" mapstructure:"-"`
 }
 
 // NewOptions creates Options object with default parameters.
@@ -75,8 +86,7 @@ func NewOptions() *Options {
 func (o *Options) Validate() []error {
 	var errs []error
 
-	var zapLevel zapcore.Level
-	if err := zapLevel.UnmarshalText([]byte(o.Level)); err != nil {
+	if _, err := parseLevel(o.Level); err != nil {
 		errs = append(errs, err)
 	}
 
@@ -106,8 +116,14 @@ func (o *Options) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.Name, flagName, o.Name, "The name of the logger.")
 	fs.BoolVar(&o.DisableStacktrace, flagDisableStacktrace,
 		o.DisableStacktrace, "Disable the log to record a stack trace for all messages at or above panic level.")
-	fs.IntVar(&o.MaxSizeInMB, flagMaxSizeInMB, o.MaxSizeInMB, "The max size in MB.")
-	fs.IntVar(&o.MaxAgeInDays, flagMaxAgeInDays, o.MaxAgeInDays, "The max age in Days.")
+	fs.IntVar(&o.MaxSizeInMB, flagMaxSizeInMB, o.MaxSizeInMB,
+		"The max size in MB of a log file before it gets rotated. Plain file path "+
+			"entries in --log.output-paths (not stdout, stderr, lumberjack: paths or "+
+			"URLs containing ://) are rotated via lumberjack once this, "+
+			"--log.max-age-days or --log.max-backups is non-zero.")
+	fs.IntVar(&o.MaxAgeInDays, flagMaxAgeInDays, o.MaxAgeInDays, "The max age in days to retain rotated log files.")
+	fs.IntVar(&o.MaxBackups, flagMaxBackups, o.MaxBackups, "The max number of rotated log files to retain.")
+	fs.BoolVar(&o.Compress, flagCompress, o.Compress, "Compress rotated log files with gzip.")
 }
 
 func (o *Options) String() string {
@@ -0,0 +1,145 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package log
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// lumberjackScheme is the zap output-path scheme that routes writes
+// through gopkg.in/natefinch/lumberjack.v2 instead of a plain file.
+const lumberjackScheme = "lumberjack"
+
+var registerLumberjackSinkOnce sync.Once
+
+// registerLumberjackSink wires the lumberjackScheme into zap's sink
+// registry. zap.RegisterSink returns an error if called twice for the same
+// scheme, which this function turns into a panic, so registration is
+// guarded with a sync.Once to make NewLogger safely callable more than
+// once per process.
+func registerLumberjackSink() {
+	registerLumberjackSinkOnce.Do(func() {
+		if err := zap.RegisterSink(lumberjackScheme, newLumberjackSink); err != nil {
+			panic(fmt.Sprintf("log: failed to register %s sink: %v", lumberjackScheme, err))
+		}
+	})
+}
+
+// lumberjackSink adapts *lumberjack.Logger to zap.Sink, which requires a
+// Sync method in addition to io.WriteCloser.
+type lumberjackSink struct {
+	*lumberjack.Logger
+}
+
+func (lumberjackSink) Sync() error { return nil }
+
+// newLumberjackSink is the zap sink factory registered for
+// lumberjackScheme. The target file path is carried as the URL's opaque
+// or path component and the rotation settings as query parameters, as
+// written by lumberjackOutputPath.
+func newLumberjackSink(u *url.URL) (zap.Sink, error) {
+	path := u.Opaque
+	if path == "" {
+		path = u.Path
+	}
+
+	query := u.Query()
+
+	maxSize, err := atoiQuery(query, "maxsize")
+	if err != nil {
+		return nil, err
+	}
+
+	maxAge, err := atoiQuery(query, "maxage")
+	if err != nil {
+		return nil, err
+	}
+
+	maxBackups, err := atoiQuery(query, "maxbackups")
+	if err != nil {
+		return nil, err
+	}
+
+	return lumberjackSink{&lumberjack.Logger{
+		Filename:   path,
+		MaxSize:    maxSize,
+		MaxAge:     maxAge,
+		MaxBackups: maxBackups,
+		Compress:   query.Get("compress") == "true",
+	}}, nil
+}
+
+func atoiQuery(query url.Values, key string) (int, error) {
+	v := query.Get(key)
+	if v == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("log: invalid %s %q: %w", key, v, err)
+	}
+
+	return n, nil
+}
+
+// lumberjackOutputPath rewrites path into a lumberjackScheme URL carrying
+// opts' rotation settings, so that newLumberjackSink can recover them.
+func lumberjackOutputPath(path string, opts *Options) string {
+	return fmt.Sprintf(
+		"%s:%s?maxsize=%d&maxage=%d&maxbackups=%d&compress=%t",
+		lumberjackScheme, path, opts.MaxSizeInMB, opts.MaxAgeInDays, opts.MaxBackups, opts.Compress,
+	)
+}
+
+// isBuiltinOutputPath reports whether path is one of zap's built-in
+// destinations or already carries an explicit scheme, in which case it
+// must be left untouched.
+func isBuiltinOutputPath(path string) bool {
+	return path == "stdout" || path == "stderr" ||
+		strings.Contains(path, "://") || strings.HasPrefix(path, lumberjackScheme+":")
+}
+
+// rotatingOutputPaths rewrites the plain file paths in paths into
+// lumberjackScheme URLs when opts requests log rotation, leaving stdout,
+// stderr and already-schemed paths untouched.
+func rotatingOutputPaths(paths []string, opts *Options) []string {
+	if opts.MaxSizeInMB == 0 && opts.MaxAgeInDays == 0 && opts.MaxBackups == 0 {
+		return paths
+	}
+
+	rewritten := make([]string, len(paths))
+	for i, p := range paths {
+		if isBuiltinOutputPath(p) {
+			rewritten[i] = p
+			continue
+		}
+
+		rewritten[i] = lumberjackOutputPath(p, opts)
+	}
+
+	return rewritten
+}
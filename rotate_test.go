@@ -0,0 +1,123 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package log
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestIsBuiltinOutputPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"stdout", true},
+		{"stderr", true},
+		{"lumberjack:./app.log?maxsize=1", true},
+		{"file:///var/log/app.log", true},
+		{"/var/log/app.log", false},
+		{"./app.log", false},
+		{"app.log", false},
+		// A bare colon must NOT be mistaken for an explicit scheme: this is
+		// the case dd15fbd tightened the check for.
+		{"/var/log/host:8080.log", false},
+	}
+
+	for _, tt := range tests {
+		if got := isBuiltinOutputPath(tt.path); got != tt.want {
+			t.Errorf("isBuiltinOutputPath(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRotatingOutputPaths(t *testing.T) {
+	t.Run("no rotation configured leaves paths untouched", func(t *testing.T) {
+		opts := NewOptions()
+		paths := []string{"stdout", "/var/log/app.log"}
+
+		got := rotatingOutputPaths(paths, opts)
+		if len(got) != len(paths) || got[0] != paths[0] || got[1] != paths[1] {
+			t.Fatalf("rotatingOutputPaths(%v, opts) = %v, want unchanged %v", paths, got, paths)
+		}
+	})
+
+	t.Run("rotation configured rewrites plain paths only", func(t *testing.T) {
+		opts := NewOptions()
+		opts.MaxSizeInMB = 100
+		opts.MaxAgeInDays = 7
+		opts.MaxBackups = 3
+		opts.Compress = true
+
+		got := rotatingOutputPaths([]string{"stdout", "/var/log/host:8080.log"}, opts)
+
+		if got[0] != "stdout" {
+			t.Errorf("stdout path was rewritten: got %q", got[0])
+		}
+
+		want := "lumberjack:/var/log/host:8080.log?maxsize=100&maxage=7&maxbackups=3&compress=true"
+		if got[1] != want {
+			t.Errorf("rotatingOutputPaths rewrote plain path to %q, want %q", got[1], want)
+		}
+	})
+
+	t.Run("already-schemed paths are left alone", func(t *testing.T) {
+		opts := NewOptions()
+		opts.MaxSizeInMB = 50
+
+		path := "lumberjack:./already-rotating.log?maxsize=10"
+		got := rotatingOutputPaths([]string{path}, opts)
+
+		if got[0] != path {
+			t.Errorf("rotatingOutputPaths rewrote an already-schemed path: got %q, want %q", got[0], path)
+		}
+	})
+}
+
+func TestLumberjackOutputPathRoundTrip(t *testing.T) {
+	opts := NewOptions()
+	opts.MaxSizeInMB = 10
+	opts.MaxAgeInDays = 2
+	opts.MaxBackups = 1
+	opts.Compress = true
+
+	rawURL := lumberjackOutputPath("./app.log", opts)
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("url.Parse(%q): %v", rawURL, err)
+	}
+
+	sink, err := newLumberjackSink(parsed)
+	if err != nil {
+		t.Fatalf("newLumberjackSink(%q): %v", rawURL, err)
+	}
+
+	lj, ok := sink.(lumberjackSink)
+	if !ok {
+		t.Fatalf("expected a lumberjackSink, got %T", sink)
+	}
+
+	if lj.Filename != "./app.log" {
+		t.Errorf("Filename = %q, want %q", lj.Filename, "./app.log")
+	}
+	if lj.MaxSize != 10 || lj.MaxAge != 2 || lj.MaxBackups != 1 || !lj.Compress {
+		t.Errorf("lumberjack.Logger = %+v, want MaxSize=10 MaxAge=2 MaxBackups=1 Compress=true", lj.Logger)
+	}
+}
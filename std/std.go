@@ -0,0 +1,49 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+// Package std redirects the standard library's default log.Logger
+// writer into this repository's log.Logger.
+package std
+
+import (
+	"bytes"
+	stdlog "log"
+
+	"github.com/huanghe314/log-tke"
+)
+
+// RedirectStdLog replaces the standard library's default *log.Logger
+// writer so that anything logged through it (including output from
+// packages that only know about the std log package) flows through l
+// instead.
+func RedirectStdLog(l log.Logger) {
+	stdlog.SetFlags(0)
+	stdlog.SetOutput(&writer{l: l})
+}
+
+// writer adapts log.Logger to io.Writer, stripping the trailing newline
+// the standard library log package always appends.
+type writer struct {
+	l log.Logger
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	w.l.Info(string(bytes.TrimRight(p, "\n")))
+
+	return len(p), nil
+}
@@ -0,0 +1,51 @@
+/*
+ * Tencent is pleased to support the open source community by making TKEStack
+ * available.
+ *
+ * Copyright (C) 2012-2019 Tencent. All Rights Reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+ * this file except in compliance with the License. You may obtain a copy of the
+ * License at
+ *
+ * https://opensource.org/licenses/Apache-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OF ANY KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations under the License.
+ */
+
+package std
+
+import (
+	stdlog "log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	log "github.com/huanghe314/log-tke"
+)
+
+func TestRedirectStdLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "out.log")
+	opts := log.NewOptions()
+	opts.OutputPaths = []string{path}
+	l := log.NewLogger(opts)
+
+	RedirectStdLog(l)
+	defer stdlog.SetOutput(os.Stderr)
+
+	stdlog.Println("via standard log package")
+	l.Flush()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+
+	if !strings.Contains(string(data), "via standard log package") {
+		t.Fatalf("expected stdlib log output to flow through l, got:\n%s", data)
+	}
+}